@@ -0,0 +1,118 @@
+package ringcache
+
+import "container/list"
+
+// Policy decides which key to evict when a cache needs to make room for a
+// new entry. It lets the fixed-size container (PolicyCache) and the
+// eviction algorithm vary independently, so callers can A/B FIFO, LRU, or
+// 2Q behaviour without changing call sites.
+type Policy interface {
+	// RecordAccess notifies the policy that key was looked up (a cache
+	// hit), so it can update whatever recency bookkeeping it keeps.
+	RecordAccess(key interface{})
+
+	// RecordInsert notifies the policy that key is being inserted fresh.
+	// If making room requires evicting an existing key, it returns that
+	// key and true; otherwise it returns (nil, false).
+	RecordInsert(key interface{}) (evict interface{}, ok bool)
+
+	// RecordRemove notifies the policy that key has left the cache, by
+	// explicit removal, expiry, or some other means, so it can drop its
+	// bookkeeping for that key.
+	RecordRemove(key interface{})
+}
+
+// FIFORingPolicy evicts keys in the order they were inserted, using the
+// same ring-hand approach as the original RingCache: it advances a hand
+// around a fixed-size slot array and evicts whatever key currently sits at
+// the hand, ignoring access patterns entirely.
+type FIFORingPolicy struct {
+	maxSize int
+	hand    int
+	keys    []interface{}
+	index   map[interface{}]int
+}
+
+// NewFIFORingPolicy creates a FIFORingPolicy for a cache of the given size.
+func NewFIFORingPolicy(maxSize int) *FIFORingPolicy {
+	return &FIFORingPolicy{
+		maxSize: maxSize,
+		keys:    make([]interface{}, maxSize),
+		index:   make(map[interface{}]int),
+	}
+}
+
+// RecordAccess is a no-op: FIFO eviction order does not depend on access
+// patterns.
+func (p *FIFORingPolicy) RecordAccess(key interface{}) {}
+
+// RecordInsert advances the ring hand and evicts whatever key occupies that
+// slot, if any.
+func (p *FIFORingPolicy) RecordInsert(key interface{}) (evict interface{}, ok bool) {
+	i := p.hand
+	if k := p.keys[i]; k != nil {
+		evict, ok = k, true
+		delete(p.index, k)
+	}
+	p.keys[i] = key
+	p.index[key] = i
+	p.hand = (i + 1) % p.maxSize
+	return
+}
+
+// RecordRemove drops key from the ring, freeing its slot for the next key
+// the hand lands on.
+func (p *FIFORingPolicy) RecordRemove(key interface{}) {
+	if i, ok := p.index[key]; ok {
+		p.keys[i] = nil
+		delete(p.index, key)
+	}
+}
+
+// LRUPolicy evicts the least recently used key once the cache is at
+// capacity, via a doubly-linked list of keys ordered from most to least
+// recently used.
+type LRUPolicy struct {
+	maxSize int
+	order   *list.List
+	index   map[interface{}]*list.Element
+}
+
+// NewLRUPolicy creates an LRUPolicy for a cache of the given size.
+func NewLRUPolicy(maxSize int) *LRUPolicy {
+	return &LRUPolicy{
+		maxSize: maxSize,
+		order:   list.New(),
+		index:   make(map[interface{}]*list.Element),
+	}
+}
+
+// RecordAccess moves key to the front of the recency list.
+func (p *LRUPolicy) RecordAccess(key interface{}) {
+	if el, ok := p.index[key]; ok {
+		p.order.MoveToFront(el)
+	}
+}
+
+// RecordInsert adds key as the most recently used entry, evicting the
+// least recently used one if the cache is already at capacity.
+func (p *LRUPolicy) RecordInsert(key interface{}) (evict interface{}, ok bool) {
+	if p.order.Len() >= p.maxSize {
+		if oldest := p.order.Back(); oldest != nil {
+			evict, ok = oldest.Value, true
+			p.order.Remove(oldest)
+			delete(p.index, evict)
+		}
+	}
+
+	p.index[key] = p.order.PushFront(key)
+	return
+}
+
+// RecordRemove drops key from the recency list.
+func (p *LRUPolicy) RecordRemove(key interface{}) {
+	if el, ok := p.index[key]; ok {
+		p.order.Remove(el)
+		delete(p.index, key)
+	}
+}
@@ -0,0 +1,135 @@
+package ringcache
+
+import "errors"
+
+// PolicyCache is a fixed-size cache whose eviction order is delegated to a
+// Policy, letting the storage and the eviction algorithm be chosen
+// independently. Use NewLRU or NewTwoQueue for the common cases, or
+// NewWithPolicy to plug in a custom Policy.
+type PolicyCache struct {
+	maxSize int
+	items   map[interface{}]interface{}
+	policy  Policy
+	onEvict EvictCallback
+}
+
+// NewWithPolicy constructs a PolicyCache of the given size using policy to
+// decide evictions, with an optional callback.
+func NewWithPolicy(maxSize int, onEvict EvictCallback, policy Policy) (*PolicyCache, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("cache size should be greater than zero")
+	}
+	return &PolicyCache{
+		maxSize: maxSize,
+		items:   make(map[interface{}]interface{}),
+		policy:  policy,
+		onEvict: onEvict,
+	}, nil
+}
+
+// NewLRU creates a PolicyCache of the given size using LRUPolicy.
+func NewLRU(maxSize int) (*PolicyCache, error) {
+	return NewLRUWithEvict(maxSize, nil)
+}
+
+// NewLRUWithEvict is like NewLRU but also registers an eviction callback.
+func NewLRUWithEvict(maxSize int, onEvict EvictCallback) (*PolicyCache, error) {
+	return NewWithPolicy(maxSize, onEvict, NewLRUPolicy(maxSize))
+}
+
+// NewTwoQueue creates a PolicyCache of the given size using TwoQueuePolicy.
+func NewTwoQueue(maxSize int) (*PolicyCache, error) {
+	return NewTwoQueueWithEvict(maxSize, nil)
+}
+
+// NewTwoQueueWithEvict is like NewTwoQueue but also registers an eviction
+// callback.
+func NewTwoQueueWithEvict(maxSize int, onEvict EvictCallback) (*PolicyCache, error) {
+	return NewWithPolicy(maxSize, onEvict, NewTwoQueuePolicy(maxSize))
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *PolicyCache) Add(key, value interface{}) (evicted bool) {
+	if key == nil || value == nil {
+		return false
+	}
+
+	if _, exists := c.items[key]; exists {
+		c.items[key] = value
+		c.policy.RecordAccess(key)
+		return false
+	}
+
+	evictKey, evicted := c.policy.RecordInsert(key)
+	if evicted {
+		if val, ok := c.items[evictKey]; ok {
+			if c.onEvict != nil {
+				c.onEvict(evictKey, val, EvictReasonCapacity)
+			}
+			delete(c.items, evictKey)
+		}
+	}
+
+	c.items[key] = value
+
+	return evicted
+}
+
+// Get looks up a key's value from the cache.
+func (c *PolicyCache) Get(key interface{}) (interface{}, bool) {
+	value, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.policy.RecordAccess(key)
+
+	return value, true
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness
+// or deleting it for being stale.
+func (c *PolicyCache) Contains(key interface{}) bool {
+	_, ok := c.items[key]
+	return ok
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *PolicyCache) Remove(key interface{}) bool {
+	val, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	delete(c.items, key)
+	c.policy.RecordRemove(key)
+
+	if c.onEvict != nil {
+		c.onEvict(key, val, EvictReasonRemoved)
+	}
+
+	return true
+}
+
+// Purge is used to completely clear the cache.
+func (c *PolicyCache) Purge() {
+	for key, val := range c.items {
+		c.policy.RecordRemove(key)
+		if c.onEvict != nil {
+			c.onEvict(key, val, EvictReasonRemoved)
+		}
+	}
+
+	c.items = make(map[interface{}]interface{})
+}
+
+// Len returns the number of items in the cache.
+func (c *PolicyCache) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the capacity of the cache.
+func (c *PolicyCache) Cap() int {
+	return c.maxSize
+}
@@ -0,0 +1,131 @@
+package ringcache
+
+import (
+	"errors"
+	"time"
+)
+
+// Resize grows or shrinks the cache in place to newMax. Growing simply adds
+// empty slots to the ring. Shrinking evicts the oldest entries (in ring
+// order starting from the current eviction hand), firing the eviction
+// callback with EvictReasonCapacity for each, until what remains fits in
+// newMax slots.
+func (c *RingCache) Resize(newMax int) error {
+	if newMax <= 0 {
+		return errors.New("cache size should be greater than zero")
+	}
+	if newMax == c.maxSize {
+		return nil
+	}
+	if newMax > c.maxSize {
+		c.grow(newMax)
+		return nil
+	}
+	c.shrink(newMax)
+	return nil
+}
+
+// grow extends the ring with empty slots, leaving existing entries and the
+// eviction hand untouched.
+func (c *RingCache) grow(newMax int) {
+	extra := newMax - c.maxSize
+	c.keys = append(c.keys, make([]interface{}, extra)...)
+	c.values = append(c.values, make([]interface{}, extra)...)
+	c.expiresAt = append(c.expiresAt, make([]time.Time, extra)...)
+	if c.sieve {
+		c.visited = append(c.visited, make([]bool, extra)...)
+	}
+	c.maxSize = newMax
+}
+
+// shrink rebuilds the ring with newMax slots, evicting the oldest live
+// entries (starting from the eviction hand) until the rest fit.
+func (c *RingCache) shrink(newMax int) {
+	type entry struct {
+		key       interface{}
+		value     interface{}
+		expiresAt time.Time
+	}
+
+	kept := make([]entry, 0, c.maxSize)
+	for i := 0; i < c.maxSize; i++ {
+		idx := (c.next + i) % c.maxSize
+		if c.keys[idx] == nil {
+			continue
+		}
+		kept = append(kept, entry{key: c.keys[idx], value: c.values[idx], expiresAt: c.expiresAt[idx]})
+	}
+
+	if overflow := len(kept) - newMax; overflow > 0 {
+		for _, e := range kept[:overflow] {
+			if c.onEvict != nil {
+				c.onEvict(e.key, e.value, EvictReasonCapacity)
+			}
+		}
+		kept = kept[overflow:]
+	}
+
+	c.keys = make([]interface{}, newMax)
+	c.values = make([]interface{}, newMax)
+	c.expiresAt = make([]time.Time, newMax)
+	c.items = make(map[interface{}]int)
+	if c.sieve {
+		c.visited = make([]bool, newMax)
+	}
+
+	for i, e := range kept {
+		c.keys[i] = e.key
+		c.values[i] = e.value
+		c.expiresAt[i] = e.expiresAt
+		c.items[e.key] = i
+	}
+
+	c.maxSize = newMax
+	c.next = len(kept) % newMax
+}
+
+// Each calls fn for every live (non-expired) entry in the cache, in ring
+// order starting from the eviction hand. This is insertion order only while
+// every insert has landed on the hand's own slot; a SIEVE victim or a slot
+// refilled after Remove or expiry is visited at its ring position, not by
+// when it was inserted. It does not update any recency metadata.
+func (c *RingCache) Each(fn func(key, value interface{})) {
+	for i := 0; i < c.maxSize; i++ {
+		idx := (c.next + i) % c.maxSize
+		if c.keys[idx] == nil || c.isExpired(idx) {
+			continue
+		}
+		fn(c.keys[idx], c.values[idx])
+	}
+}
+
+// Range calls fn for every live (non-expired) entry in the cache, in the
+// same ring order as Each, stopping early if fn returns false. It does not
+// update any recency metadata.
+func (c *RingCache) Range(fn func(key, value interface{}) bool) {
+	for i := 0; i < c.maxSize; i++ {
+		idx := (c.next + i) % c.maxSize
+		if c.keys[idx] == nil || c.isExpired(idx) {
+			continue
+		}
+		if !fn(c.keys[idx], c.values[idx]) {
+			return
+		}
+	}
+}
+
+// Peek looks up a key's value without updating any recency metadata (such
+// as the SIEVE visited bit) and without removing it if it has expired.
+func (c *RingCache) Peek(key interface{}) (interface{}, bool) {
+	i, ok := c.items[key]
+	if !ok || c.isExpired(i) {
+		return nil, false
+	}
+
+	return c.values[i], true
+}
+
+// SetEvictCallback changes the eviction callback used for future evictions.
+func (c *RingCache) SetEvictCallback(onEvict EvictCallback) {
+	c.onEvict = onEvict
+}
@@ -2,22 +2,52 @@ package ringcache
 
 import (
 	"errors"
+	"time"
+)
+
+// EvictReason describes why an entry was evicted from the cache, passed to
+// the EvictCallback.
+type EvictReason int
+
+const (
+	// EvictReasonCapacity means the entry was evicted to make room for a
+	// new one, or to fit a smaller capacity after Resize.
+	EvictReasonCapacity EvictReason = iota
+	// EvictReasonRemoved means the entry was removed explicitly, via
+	// Remove or Purge.
+	EvictReasonRemoved
+	// EvictReasonExpired means the entry's TTL elapsed before it was
+	// naturally evicted or removed.
+	EvictReasonExpired
 )
 
 // EvictCallback is used to get a callback when a cache entry is evicted
-type EvictCallback func(key interface{}, value interface{})
+type EvictCallback func(key interface{}, value interface{}, reason EvictReason)
 
 // RingCache, often known as a circular buffer or ring buffer, is a data
 // structure that uses a single, fixed-size buffer as if it were connected
 // end-to-end. It is particularly useful for applications that require a buffer
 // with a consistent and predictable size, such as in real-time data processing
 // systems or network packet buffering.
+//
+// By default a RingCache evicts whichever slot the ring pointer lands on next,
+// giving FIFO behaviour. An empty or expired slot found ahead of the pointer
+// is filled first and the pointer moves past it, so once a slot has been
+// freed by Remove or expiry, eviction order is no longer strictly insertion
+// order. Constructing it with NewSieve/NewSieveWithEvict
+// instead enables SIEVE eviction, which uses the same ring layout but skips
+// over recently accessed slots before picking a victim.
 type RingCache struct {
-	maxSize int
-	next    int
-	keys    []interface{}
-	items   map[interface{}]interface{}
-	onEvict EvictCallback
+	maxSize    int
+	next       int
+	keys       []interface{}
+	values     []interface{}
+	expiresAt  []time.Time
+	items      map[interface{}]int
+	visited    []bool
+	sieve      bool
+	defaultTTL time.Duration
+	onEvict    EvictCallback
 }
 
 // New creates a ring cache of the given size.
@@ -27,95 +57,271 @@ func New(maxSize int) (*RingCache, error) {
 
 // NewWithEvict constructs ring cache of the given size with callback
 func NewWithEvict(maxSize int, onEvict EvictCallback) (*RingCache, error) {
+	return newRingCache(maxSize, onEvict, false, 0)
+}
+
+// NewSieve creates a ring cache of the given size that uses SIEVE eviction
+// instead of plain FIFO. SIEVE keeps a "visited" bit per slot: a lookup marks
+// its slot as visited, and the eviction hand clears visited bits as it sweeps
+// past them, only evicting a slot that was not visited since it was last
+// passed. This consistently outperforms LRU/ARC on web-like workloads while
+// keeping O(1) operations and very low metadata overhead.
+func NewSieve(maxSize int) (*RingCache, error) {
+	return NewSieveWithEvict(maxSize, nil)
+}
+
+// NewSieveWithEvict constructs a SIEVE-mode ring cache of the given size with
+// callback. See NewSieve for details on the eviction policy.
+func NewSieveWithEvict(maxSize int, onEvict EvictCallback) (*RingCache, error) {
+	return newRingCache(maxSize, onEvict, true, 0)
+}
+
+// NewWithTTL creates a ring cache of the given size where every entry added
+// via Add expires after defaultTTL has elapsed. A defaultTTL of zero means
+// entries never expire on their own, which is the same as New; use
+// AddWithTTL to set a per-entry TTL regardless of the default.
+func NewWithTTL(maxSize int, defaultTTL time.Duration) (*RingCache, error) {
+	return NewWithTTLAndEvict(maxSize, defaultTTL, nil)
+}
+
+// NewWithTTLAndEvict is like NewWithTTL but also registers an eviction
+// callback.
+func NewWithTTLAndEvict(maxSize int, defaultTTL time.Duration, onEvict EvictCallback) (*RingCache, error) {
+	return newRingCache(maxSize, onEvict, false, defaultTTL)
+}
+
+func newRingCache(maxSize int, onEvict EvictCallback, sieve bool, defaultTTL time.Duration) (*RingCache, error) {
 	if maxSize <= 0 {
 		return nil, errors.New("cache size should be greater than zero")
 	}
 	cache := &RingCache{
-		maxSize: maxSize,
-		next:    0,
-		keys:    make([]interface{}, maxSize),
-		items:   make(map[interface{}]interface{}),
-		onEvict: onEvict,
+		maxSize:    maxSize,
+		next:       0,
+		keys:       make([]interface{}, maxSize),
+		values:     make([]interface{}, maxSize),
+		expiresAt:  make([]time.Time, maxSize),
+		items:      make(map[interface{}]int),
+		sieve:      sieve,
+		defaultTTL: defaultTTL,
+		onEvict:    onEvict,
+	}
+	if sieve {
+		cache.visited = make([]bool, maxSize)
 	}
 
 	return cache, nil
 }
 
+// isExpired reports whether the entry in slot i has a TTL and it has
+// elapsed. A zero expiresAt means the entry never expires.
+func (c *RingCache) isExpired(i int) bool {
+	return !c.expiresAt[i].IsZero() && time.Now().After(c.expiresAt[i])
+}
+
 // Purge is used to completely clear the cache.
 func (c *RingCache) Purge() {
 	// evict all items
 	if c.onEvict != nil {
-		for _, k := range c.keys {
+		for i, k := range c.keys {
 			if k != nil {
-				c.onEvict(k, c.items[k])
+				c.onEvict(k, c.values[i], EvictReasonRemoved)
 			}
 		}
 	}
 
 	// re-initialize
-	c.items = make(map[interface{}]interface{})
+	c.items = make(map[interface{}]int)
 	c.keys = make([]interface{}, c.maxSize)
+	c.values = make([]interface{}, c.maxSize)
+	c.expiresAt = make([]time.Time, c.maxSize)
+	if c.sieve {
+		c.visited = make([]bool, c.maxSize)
+	}
 	c.next = 0
 }
 
-// Add adds a value to the cache. Returns true if an eviction occurred.
+// Add adds a value to the cache, using the cache's default TTL (none,
+// unless the cache was created with NewWithTTL). Returns true if an
+// eviction occurred.
 func (c *RingCache) Add(key, value interface{}) (evicted bool) {
-	evicted = false
+	return c.AddWithTTL(key, value, c.defaultTTL)
+}
 
+// AddWithTTL adds a value to the cache that expires after ttl, regardless of
+// the cache's default TTL. A ttl of zero means the entry never expires.
+// Returns true if an eviction occurred.
+func (c *RingCache) AddWithTTL(key, value interface{}, ttl time.Duration) (evicted bool) {
 	// Do nothing if key or value is nil
 	if key == nil || value == nil {
-		return
+		return false
+	}
+
+	// Overwrite in place if the key is already present, rather than
+	// inserting a second copy into a new slot and leaving the original
+	// slot's eventual eviction orphan the live entry.
+	if i, ok := c.items[key]; ok {
+		c.values[i] = value
+		if ttl > 0 {
+			c.expiresAt[i] = time.Now().Add(ttl)
+		} else {
+			c.expiresAt[i] = time.Time{}
+		}
+		return false
 	}
 
-	// Check for existing item
-	if k := c.keys[c.next]; k != nil {
+	i := c.next
+	if c.sieve {
+		// Advance the hand, clearing visited bits, until it lands on an
+		// empty slot, an expired slot, or one that was not visited since
+		// last passed. Expired slots are always fair game, even if they
+		// were visited, since their data is already stale.
+		for c.keys[i] != nil && !c.isExpired(i) && c.visited[i] {
+			c.visited[i] = false
+			i = (i + 1) % c.maxSize
+		}
+	} else {
+		// Look for an empty or already-expired slot to reclaim ahead of the ring
+		// hand, rather than leaving it to occupy capacity until the hand
+		// reaches it naturally. Give up after a full lap and fall back
+		// to strict FIFO order at c.next.
+		for j, steps := i, 0; steps < c.maxSize; j, steps = (j+1)%c.maxSize, steps+1 {
+			if c.keys[j] == nil || c.isExpired(j) {
+				i = j
+				break
+			}
+		}
+	}
+
+	// Check for existing item occupying the victim slot
+	if k := c.keys[i]; k != nil {
+		reason := EvictReasonCapacity
+		if c.isExpired(i) {
+			reason = EvictReasonExpired
+		}
 		if c.onEvict != nil {
-			c.onEvict(k, c.items[k])
-			evicted = true
+			c.onEvict(k, c.values[i], reason)
 		}
 		delete(c.items, k)
+		evicted = true
 	}
 
-	c.items[key] = value
-	c.keys[c.next] = key
-	c.next = (c.next + 1) % c.maxSize
+	c.items[key] = i
+	c.keys[i] = key
+	c.values[i] = value
+	if ttl > 0 {
+		c.expiresAt[i] = time.Now().Add(ttl)
+	} else {
+		c.expiresAt[i] = time.Time{}
+	}
+	if c.sieve {
+		c.visited[i] = false
+	}
+	c.next = (i + 1) % c.maxSize
 
 	return
 }
 
-// Get looks up a key's value from the cache.
+// Get looks up a key's value from the cache. An expired entry is treated as
+// a miss and is removed, firing the eviction callback with EvictReasonExpired.
 func (c *RingCache) Get(key interface{}) (interface{}, bool) {
-	value, ok := c.items[key]
+	i, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.isExpired(i) {
+		c.expire(key, i)
+		return nil, false
+	}
 
-	return value, ok
+	if c.sieve {
+		c.visited[i] = true
+	}
+
+	return c.values[i], true
 }
 
-// Contains checks if a key is in the cache, without updating the recent-ness
-// or deleting it for being stale.
+// Contains checks if a key is in the cache. An expired entry is treated as
+// a miss and is removed, firing the
+// eviction callback with EvictReasonExpired. In SIEVE mode, Contains counts
+// as an access and marks the entry visited, the same as Get.
 func (c *RingCache) Contains(key interface{}) bool {
-	_, ok := c.items[key]
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
 
-	return ok
+	if c.isExpired(i) {
+		c.expire(key, i)
+		return false
+	}
+
+	if c.sieve {
+		c.visited[i] = true
+	}
+
+	return true
 }
 
 // Remove removes the provided key from the cache, returning if the
 // key was contained.
 func (c *RingCache) Remove(key interface{}) bool {
-	if val, ok := c.items[key]; ok {
-		delete(c.items, key)
-		for i, k := range c.keys {
-			if k == key {
-				c.keys[i] = nil
-				if c.onEvict != nil {
-					c.onEvict(key, val)
-				}
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
 
-				return true
-			}
+	val := c.values[i]
+	c.clearSlot(key, i)
+
+	if c.onEvict != nil {
+		c.onEvict(key, val, EvictReasonRemoved)
+	}
+
+	return true
+}
+
+// RemoveExpired sweeps the cache for entries whose TTL has elapsed, removing
+// them and firing the eviction callback with EvictReasonExpired for each.
+// It returns the number of entries removed. Callers with long-lived TTL
+// caches typically run this periodically from a background goroutine so
+// that expired entries don't merely sit lazily until looked up.
+func (c *RingCache) RemoveExpired() int {
+	removed := 0
+	for i, k := range c.keys {
+		if k == nil || !c.isExpired(i) {
+			continue
+		}
+		val := c.values[i]
+		c.clearSlot(k, i)
+		if c.onEvict != nil {
+			c.onEvict(k, val, EvictReasonExpired)
 		}
+		removed++
 	}
+	return removed
+}
 
-	return false
+// expire removes the entry in slot i for key, firing the eviction callback
+// with EvictReasonExpired.
+func (c *RingCache) expire(key interface{}, i int) {
+	val := c.values[i]
+	c.clearSlot(key, i)
+	if c.onEvict != nil {
+		c.onEvict(key, val, EvictReasonExpired)
+	}
+}
+
+// clearSlot removes key from the items map and resets slot i, without
+// firing the eviction callback.
+func (c *RingCache) clearSlot(key interface{}, i int) {
+	delete(c.items, key)
+	c.keys[i] = nil
+	c.values[i] = nil
+	c.expiresAt[i] = time.Time{}
+	if c.sieve {
+		c.visited[i] = false
+	}
 }
 
 // Len returns the number of items in the cache.
@@ -0,0 +1,141 @@
+package ringcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddOverwritesExistingKeyInPlace(t *testing.T) {
+	c, err := New(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("a", 99)
+	c.Add("c", 3)
+	c.Add("d", 4)
+
+	if v, ok := c.Get("a"); !ok || v != 99 {
+		t.Fatalf("Get(a) = %v, %v; want 99, true", v, ok)
+	}
+	if c.Len() != 4 {
+		t.Fatalf("Len() = %d; want 4", c.Len())
+	}
+}
+
+func TestSieveWrapAroundEvictsUnvisited(t *testing.T) {
+	var evicted []interface{}
+	c, err := NewSieveWithEvict(3, func(key, value interface{}, reason EvictReason) {
+		evicted = append(evicted, key)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+	c.Add(3, "c")
+
+	// Mark 1 and 2 as visited; 3 is left untouched and should be the
+	// victim once the hand wraps around looking for a new slot.
+	c.Get(1)
+	c.Get(2)
+
+	if !c.Add(4, "d") {
+		t.Fatal("expected an eviction")
+	}
+	if len(evicted) != 1 || evicted[0] != 3 {
+		t.Fatalf("evicted = %v; want [3]", evicted)
+	}
+	if c.Contains(3) {
+		t.Fatal("expected key 3 to be evicted")
+	}
+	if !c.Contains(1) || !c.Contains(2) || !c.Contains(4) {
+		t.Fatal("expected 1, 2, and 4 to survive")
+	}
+
+	// The Contains checks above marked 1, 2, and 4 visited again. A second
+	// insert sweeps a full lap clearing all three bits and should then
+	// evict 1 (the slot the hand started from).
+	evicted = nil
+	if !c.Add(5, "e") {
+		t.Fatal("expected a second eviction")
+	}
+	if len(evicted) != 1 || evicted[0] != 1 {
+		t.Fatalf("evicted = %v; want [1]", evicted)
+	}
+}
+
+func TestGetLazilyExpiresEntry(t *testing.T) {
+	var evicted []EvictReason
+	c, err := NewWithTTLAndEvict(2, 0, func(key, value interface{}, reason EvictReason) {
+		evicted = append(evicted, reason)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.AddWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to be a miss")
+	}
+	if c.Contains("a") {
+		t.Fatal("expected expired entry to be gone")
+	}
+	if len(evicted) != 1 || evicted[0] != EvictReasonExpired {
+		t.Fatalf("evicted reasons = %v; want [EvictReasonExpired]", evicted)
+	}
+}
+
+func TestRemoveExpiredSweepsStaleEntries(t *testing.T) {
+	c, err := New(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.AddWithTTL("a", 1, time.Millisecond)
+	c.AddWithTTL("b", 2, time.Hour)
+	c.Add("c", 3)
+	time.Sleep(5 * time.Millisecond)
+
+	if n := c.RemoveExpired(); n != 1 {
+		t.Fatalf("RemoveExpired() = %d; want 1", n)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d; want 2", c.Len())
+	}
+	if !c.Contains("b") || !c.Contains("c") {
+		t.Fatal("expected unexpired entries to survive the sweep")
+	}
+}
+
+func TestResizeShrinkKeepsNewestEntries(t *testing.T) {
+	var evicted []interface{}
+	c, err := NewWithEvict(4, func(key, value interface{}, reason EvictReason) {
+		evicted = append(evicted, key)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+	c.Add(3, "c")
+
+	if err := c.Resize(2); err != nil {
+		t.Fatal(err)
+	}
+	if c.Cap() != 2 {
+		t.Fatalf("Cap() = %d; want 2", c.Cap())
+	}
+	if len(evicted) != 1 || evicted[0] != 1 {
+		t.Fatalf("evicted = %v; want [1] (the oldest entry)", evicted)
+	}
+	if !c.Contains(2) || !c.Contains(3) {
+		t.Fatal("expected the two newest entries to survive the shrink")
+	}
+}
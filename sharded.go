@@ -0,0 +1,240 @@
+package ringcache
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"sync"
+)
+
+// KeyHasher computes a shard-selection hash for a key. The low bits of the
+// returned value are used to pick a shard, so a good general-purpose hash
+// (the default uses FNV-1a) is sufficient.
+type KeyHasher func(key interface{}) uint64
+
+type shardEviction struct {
+	key    interface{}
+	value  interface{}
+	reason EvictReason
+}
+
+// ShardedRingCache fans keys across a number of independent RingCache shards,
+// each guarded by its own sync.RWMutex, so it can be used concurrently from
+// multiple goroutines without serializing every access on a single lock.
+// This is intended for high-throughput uses such as a packet or connection
+// cache in a network server.
+type ShardedRingCache struct {
+	shards    []*RingCache
+	locks     []sync.RWMutex
+	shardMask uint64
+	hasher    KeyHasher
+
+	onEvict EvictCallback
+	evictCh chan shardEviction
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewSharded creates a sharded ring cache with the given total capacity,
+// spread evenly across runtime.GOMAXPROCS(0) shards (rounded up to a power
+// of two). When that exceeds maxSize, the shard count is the largest power
+// of two not above maxSize instead.
+func NewSharded(maxSize int) (*ShardedRingCache, error) {
+	return NewShardedWithEvict(maxSize, nil)
+}
+
+// NewShardedWithEvict constructs a sharded ring cache of the given total
+// capacity with an eviction callback. The callback is invoked from a single
+// background goroutine, outside of any shard lock, so it is safe for it to
+// call back into the cache.
+func NewShardedWithEvict(maxSize int, onEvict EvictCallback) (*ShardedRingCache, error) {
+	return NewShardedWithHasher(maxSize, onEvict, nil)
+}
+
+// NewShardedWithHasher is like NewShardedWithEvict but allows the caller to
+// supply their own shard-selection hash instead of the default FNV-1a one.
+func NewShardedWithHasher(maxSize int, onEvict EvictCallback, hasher KeyHasher) (*ShardedRingCache, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("cache size should be greater than zero")
+	}
+	if hasher == nil {
+		hasher = fnvHash
+	}
+
+	// Cap shardCount to maxSize (keeping it a power of two, so the mask-based
+	// shard lookup in shardFor stays valid) so that every shard can hold at
+	// least one entry and the shards' capacities sum to exactly maxSize.
+	shardCount := nextPowerOfTwo(runtime.GOMAXPROCS(0))
+	if shardCount > maxSize {
+		shardCount = prevPowerOfTwo(maxSize)
+	}
+	shardSize, remainder := maxSize/shardCount, maxSize%shardCount
+
+	c := &ShardedRingCache{
+		shards:    make([]*RingCache, shardCount),
+		locks:     make([]sync.RWMutex, shardCount),
+		shardMask: uint64(shardCount - 1),
+		hasher:    hasher,
+		onEvict:   onEvict,
+	}
+
+	if onEvict != nil {
+		c.evictCh = make(chan shardEviction, maxSize)
+		c.done = make(chan struct{})
+		c.wg.Add(1)
+		go c.runEvictions()
+	}
+
+	var shardCallback EvictCallback
+	if onEvict != nil {
+		shardCallback = c.shardEvicted
+	}
+
+	for i := range c.shards {
+		size := shardSize
+		if i < remainder {
+			// Distribute the remainder across the first few shards so
+			// the shards' capacities sum to exactly maxSize.
+			size++
+		}
+		shard, err := NewWithEvict(size, shardCallback)
+		if err != nil {
+			return nil, err
+		}
+		c.shards[i] = shard
+	}
+
+	return c, nil
+}
+
+func (c *ShardedRingCache) shardEvicted(key, value interface{}, reason EvictReason) {
+	c.evictCh <- shardEviction{key: key, value: value, reason: reason}
+}
+
+func (c *ShardedRingCache) runEvictions() {
+	defer c.wg.Done()
+	for {
+		select {
+		case ev := <-c.evictCh:
+			c.onEvict(ev.key, ev.value, ev.reason)
+		case <-c.done:
+			// Drain whatever is left before exiting.
+			for {
+				select {
+				case ev := <-c.evictCh:
+					c.onEvict(ev.key, ev.value, ev.reason)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close stops the background eviction-callback goroutine. It is a no-op if
+// the cache was constructed without an eviction callback.
+func (c *ShardedRingCache) Close() {
+	if c.done == nil {
+		return
+	}
+	close(c.done)
+	c.wg.Wait()
+}
+
+func (c *ShardedRingCache) shardFor(key interface{}) (*RingCache, *sync.RWMutex) {
+	idx := c.hasher(key) & c.shardMask
+	return c.shards[idx], &c.locks[idx]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ShardedRingCache) Add(key, value interface{}) (evicted bool) {
+	shard, lock := c.shardFor(key)
+	lock.Lock()
+	defer lock.Unlock()
+	return shard.Add(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (c *ShardedRingCache) Get(key interface{}) (interface{}, bool) {
+	shard, lock := c.shardFor(key)
+	lock.RLock()
+	defer lock.RUnlock()
+	return shard.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness
+// or deleting it for being stale.
+func (c *ShardedRingCache) Contains(key interface{}) bool {
+	shard, lock := c.shardFor(key)
+	lock.RLock()
+	defer lock.RUnlock()
+	return shard.Contains(key)
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *ShardedRingCache) Remove(key interface{}) bool {
+	shard, lock := c.shardFor(key)
+	lock.Lock()
+	defer lock.Unlock()
+	return shard.Remove(key)
+}
+
+// Purge is used to completely clear the cache.
+func (c *ShardedRingCache) Purge() {
+	for i, shard := range c.shards {
+		c.locks[i].Lock()
+		shard.Purge()
+		c.locks[i].Unlock()
+	}
+}
+
+// Len returns the number of items in the cache, summed across shards.
+func (c *ShardedRingCache) Len() int {
+	total := 0
+	for i, shard := range c.shards {
+		c.locks[i].RLock()
+		total += shard.Len()
+		c.locks[i].RUnlock()
+	}
+	return total
+}
+
+// Cap returns the total capacity of the cache, summed across shards.
+func (c *ShardedRingCache) Cap() int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.Cap()
+	}
+	return total
+}
+
+func fnvHash(key interface{}) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v", key)
+	return h.Sum64()
+}
+
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// prevPowerOfTwo returns the largest power of two less than or equal to n.
+func prevPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p<<1 <= n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,27 @@
+package ringcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardedWithoutCallbackDoesNotDeadlockOnEviction(t *testing.T) {
+	c, err := NewSharded(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			c.Add(i, i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Add deadlocked evicting with no eviction callback registered")
+	}
+}
@@ -0,0 +1,156 @@
+package ringcache
+
+import "container/list"
+
+// TwoQueuePolicy implements the 2Q replacement algorithm: a small FIFO
+// queue (A1in) absorbs one-hit-wonders without polluting the main LRU
+// (Am), a ghost list (A1out) remembers keys recently evicted from A1in so a
+// second touch promotes them straight into Am, and Am itself is a plain
+// LRU. By default A1in is sized to roughly 25% of the cache and A1out to
+// roughly 50%, per the original 2Q paper.
+type TwoQueuePolicy struct {
+	maxSize int
+	kIn     int
+	kOut    int
+
+	a1in  *list.List
+	a1out *list.List
+	am    *list.List
+
+	inIndex  map[interface{}]*list.Element
+	outIndex map[interface{}]*list.Element
+	amIndex  map[interface{}]*list.Element
+}
+
+// NewTwoQueuePolicy creates a TwoQueuePolicy for a cache of the given size.
+func NewTwoQueuePolicy(maxSize int) *TwoQueuePolicy {
+	kIn := maxSize / 4
+	if kIn < 1 {
+		kIn = 1
+	}
+	kOut := maxSize / 2
+	if kOut < 1 {
+		kOut = 1
+	}
+
+	return &TwoQueuePolicy{
+		maxSize:  maxSize,
+		kIn:      kIn,
+		kOut:     kOut,
+		a1in:     list.New(),
+		a1out:    list.New(),
+		am:       list.New(),
+		inIndex:  make(map[interface{}]*list.Element),
+		outIndex: make(map[interface{}]*list.Element),
+		amIndex:  make(map[interface{}]*list.Element),
+	}
+}
+
+// RecordAccess promotes a hit in Am to most-recently-used. A hit in A1in is
+// left alone: 2Q treats A1in as a plain FIFO, so a single access doesn't
+// protect an entry from its first eviction.
+func (p *TwoQueuePolicy) RecordAccess(key interface{}) {
+	if el, ok := p.amIndex[key]; ok {
+		p.am.MoveToFront(el)
+	}
+}
+
+// RecordInsert inserts a key seen for the first time into A1in, or, if the
+// key is a ghost in A1out (a second touch), promotes it straight into Am.
+func (p *TwoQueuePolicy) RecordInsert(key interface{}) (evict interface{}, ok bool) {
+	if el, isGhost := p.outIndex[key]; isGhost {
+		p.a1out.Remove(el)
+		delete(p.outIndex, key)
+
+		if p.liveLen() >= p.maxSize {
+			evict, ok = p.makeRoom()
+		}
+		p.amIndex[key] = p.am.PushFront(key)
+		return
+	}
+
+	// Per the 2Q paper, eviction only happens once the cache is actually
+	// full; A1in growing past its kIn quota on its own is not a reason to
+	// evict while there is still free capacity elsewhere.
+	if p.liveLen() >= p.maxSize {
+		evict, ok = p.makeRoom()
+	}
+	p.inIndex[key] = p.a1in.PushFront(key)
+	return
+}
+
+// RecordRemove drops key from whichever of A1in, Am, or A1out it is in.
+func (p *TwoQueuePolicy) RecordRemove(key interface{}) {
+	if el, ok := p.inIndex[key]; ok {
+		p.a1in.Remove(el)
+		delete(p.inIndex, key)
+		return
+	}
+	if el, ok := p.amIndex[key]; ok {
+		p.am.Remove(el)
+		delete(p.amIndex, key)
+		return
+	}
+	if el, ok := p.outIndex[key]; ok {
+		p.a1out.Remove(el)
+		delete(p.outIndex, key)
+	}
+}
+
+// liveLen is the number of keys actually holding cache space, i.e.
+// excluding the value-less A1out ghost list.
+func (p *TwoQueuePolicy) liveLen() int {
+	return p.a1in.Len() + p.am.Len()
+}
+
+// demoteA1inTail moves A1in's tail into the A1out ghost list, evicting the
+// ghost list's own tail if that pushes it past its quota.
+func (p *TwoQueuePolicy) demoteA1inTail() (key interface{}, ok bool) {
+	tail := p.a1in.Back()
+	if tail == nil {
+		return nil, false
+	}
+
+	key = tail.Value
+	p.a1in.Remove(tail)
+	delete(p.inIndex, key)
+
+	p.outIndex[key] = p.a1out.PushFront(key)
+	if p.a1out.Len() > p.kOut {
+		ghost := p.a1out.Back()
+		p.a1out.Remove(ghost)
+		delete(p.outIndex, ghost.Value)
+	}
+
+	return key, true
+}
+
+// evictAmTail evicts Am's least recently used entry outright.
+func (p *TwoQueuePolicy) evictAmTail() (key interface{}, ok bool) {
+	tail := p.am.Back()
+	if tail == nil {
+		return nil, false
+	}
+
+	key = tail.Value
+	p.am.Remove(tail)
+	delete(p.amIndex, key)
+
+	return key, true
+}
+
+// makeRoom frees space for one more live entry. It is only called once the
+// cache is full. Once A1in has grown past its kIn quota, its tail is
+// demoted into the A1out ghost list (so a near-future second touch can
+// still promote it into Am); otherwise Am's tail is evicted outright. If
+// Am is empty, everything live is still sitting in A1in even though it
+// hasn't grown past kIn, so A1in's tail is demoted regardless.
+func (p *TwoQueuePolicy) makeRoom() (evict interface{}, ok bool) {
+	if p.a1in.Len() > p.kIn {
+		return p.demoteA1inTail()
+	}
+	if evict, ok = p.evictAmTail(); ok {
+		return evict, ok
+	}
+	return p.demoteA1inTail()
+}
@@ -0,0 +1,204 @@
+// Package ringcache provides a generic, type-parameterized version of the
+// v1 RingCache. It avoids the interface{} boxing/unboxing of the v1 API and
+// the "do nothing if nil" guard that silently dropped legitimate zero values.
+package ringcache
+
+import (
+	"errors"
+)
+
+// EvictCallback is used to get a callback when a cache entry is evicted
+type EvictCallback[K comparable, V any] func(key K, value V)
+
+// RingCache, often known as a circular buffer or ring buffer, is a data
+// structure that uses a single, fixed-size buffer as if it were connected
+// end-to-end. It is particularly useful for applications that require a buffer
+// with a consistent and predictable size, such as in real-time data processing
+// systems or network packet buffering.
+//
+// By default a RingCache evicts whichever slot the ring pointer lands on next,
+// giving strict FIFO behaviour. Constructing it with NewSieve/NewSieveWithEvict
+// instead enables SIEVE eviction, which uses the same ring layout but skips
+// over recently accessed slots before picking a victim.
+type RingCache[K comparable, V any] struct {
+	maxSize  int
+	next     int
+	keys     []K
+	values   []V
+	occupied []bool
+	items    map[K]int
+	visited  []bool
+	sieve    bool
+	onEvict  EvictCallback[K, V]
+}
+
+// New creates a ring cache of the given size.
+func New[K comparable, V any](maxSize int) (*RingCache[K, V], error) {
+	return NewWithEvict[K, V](maxSize, nil)
+}
+
+// NewWithEvict constructs ring cache of the given size with callback
+func NewWithEvict[K comparable, V any](maxSize int, onEvict EvictCallback[K, V]) (*RingCache[K, V], error) {
+	return newRingCache[K, V](maxSize, onEvict, false)
+}
+
+// NewSieve creates a ring cache of the given size that uses SIEVE eviction
+// instead of plain FIFO. See the v1 package for details on the policy.
+func NewSieve[K comparable, V any](maxSize int) (*RingCache[K, V], error) {
+	return NewSieveWithEvict[K, V](maxSize, nil)
+}
+
+// NewSieveWithEvict constructs a SIEVE-mode ring cache of the given size with
+// callback.
+func NewSieveWithEvict[K comparable, V any](maxSize int, onEvict EvictCallback[K, V]) (*RingCache[K, V], error) {
+	return newRingCache[K, V](maxSize, onEvict, true)
+}
+
+func newRingCache[K comparable, V any](maxSize int, onEvict EvictCallback[K, V], sieve bool) (*RingCache[K, V], error) {
+	if maxSize <= 0 {
+		return nil, errors.New("cache size should be greater than zero")
+	}
+	cache := &RingCache[K, V]{
+		maxSize:  maxSize,
+		next:     0,
+		keys:     make([]K, maxSize),
+		values:   make([]V, maxSize),
+		occupied: make([]bool, maxSize),
+		items:    make(map[K]int),
+		sieve:    sieve,
+		onEvict:  onEvict,
+	}
+	if sieve {
+		cache.visited = make([]bool, maxSize)
+	}
+
+	return cache, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *RingCache[K, V]) Purge() {
+	// evict all items
+	if c.onEvict != nil {
+		for i, occupied := range c.occupied {
+			if occupied {
+				c.onEvict(c.keys[i], c.values[i])
+			}
+		}
+	}
+
+	// re-initialize
+	c.items = make(map[K]int)
+	c.keys = make([]K, c.maxSize)
+	c.values = make([]V, c.maxSize)
+	c.occupied = make([]bool, c.maxSize)
+	if c.sieve {
+		c.visited = make([]bool, c.maxSize)
+	}
+	c.next = 0
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *RingCache[K, V]) Add(key K, value V) (evicted bool) {
+	// Overwrite in place if the key is already present, rather than
+	// inserting a second copy into a new slot and leaving the original
+	// slot's eventual eviction orphan the live entry.
+	if i, ok := c.items[key]; ok {
+		c.values[i] = value
+		return false
+	}
+
+	i := c.next
+	if c.sieve {
+		// Advance the hand, clearing visited bits, until it lands on an
+		// empty slot or one that was not visited since last passed.
+		for c.occupied[i] && c.visited[i] {
+			c.visited[i] = false
+			i = (i + 1) % c.maxSize
+		}
+	}
+
+	// Check for existing item occupying the victim slot
+	if c.occupied[i] {
+		if c.onEvict != nil {
+			c.onEvict(c.keys[i], c.values[i])
+		}
+		delete(c.items, c.keys[i])
+		evicted = true
+	}
+
+	c.items[key] = i
+	c.keys[i] = key
+	c.values[i] = value
+	c.occupied[i] = true
+	if c.sieve {
+		c.visited[i] = false
+	}
+	c.next = (i + 1) % c.maxSize
+
+	return
+}
+
+// Get looks up a key's value from the cache.
+func (c *RingCache[K, V]) Get(key K) (value V, ok bool) {
+	i, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+
+	if c.sieve {
+		c.visited[i] = true
+	}
+
+	return c.values[i], true
+}
+
+// Contains checks if a key is in the cache. In SIEVE mode, Contains counts
+// as an access and marks the entry visited, the same as Get.
+func (c *RingCache[K, V]) Contains(key K) bool {
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	if c.sieve {
+		c.visited[i] = true
+	}
+
+	return true
+}
+
+// Remove removes the provided key from the cache, returning if the
+// key was contained.
+func (c *RingCache[K, V]) Remove(key K) bool {
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	val := c.values[i]
+	delete(c.items, key)
+	c.occupied[i] = false
+	var zeroK K
+	var zeroV V
+	c.keys[i] = zeroK
+	c.values[i] = zeroV
+	if c.sieve {
+		c.visited[i] = false
+	}
+
+	if c.onEvict != nil {
+		c.onEvict(key, val)
+	}
+
+	return true
+}
+
+// Len returns the number of items in the cache.
+func (c *RingCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the capacity of the cache.
+func (c *RingCache[K, V]) Cap() int {
+	return c.maxSize
+}